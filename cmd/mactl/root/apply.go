@@ -0,0 +1,34 @@
+package root
+
+import (
+	"github.com/leftbin/mactl/internal/manifest"
+	"github.com/leftbin/mactl/internal/preflight"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var applyFile string
+var applyDryRun bool
+
+var Apply = &cobra.Command{
+	Use:   "apply",
+	Short: "reconcile this Mac to match a declarative manifest",
+	Long: `apply reads a YAML manifest describing the desired state of this Mac -
+brew formulae/casks, git config and SSH keys, environment variables and
+dock preferences - and reconciles the machine to match it. Already
+satisfied sections are left untouched, so apply is safe to re-run.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := manifest.Load(applyFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to load manifest")
+		}
+		return manifest.Apply(cfg, applyDryRun)
+	},
+}
+
+func init() {
+	Apply.Flags().StringVarP(&applyFile, "file", "f", "", "path to the manifest YAML file (required)")
+	Apply.Flags().BoolVar(&applyDryRun, "dry-run", false, "print the actions that would be taken without executing them")
+	_ = Apply.MarkFlagRequired("file")
+	preflight.Guard(Apply, preflight.RequireDarwin(), preflight.RequireBrew(), preflight.RequireXcodeCLT())
+}
@@ -0,0 +1,23 @@
+package root
+
+import (
+	"fmt"
+
+	"github.com/leftbin/mactl/internal/preflight"
+	"github.com/spf13/cobra"
+)
+
+var Check = &cobra.Command{
+	Use:   "check",
+	Short: "run every command's preflight checks and report what's usable here",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, result := range preflight.CheckAll() {
+			if result.Usable() {
+				fmt.Fprintf(cmd.OutOrStdout(), "OK   %s\n", result.CommandPath)
+				continue
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "FAIL %s: %s\n", result.CommandPath, result.Err)
+		}
+		return nil
+	},
+}
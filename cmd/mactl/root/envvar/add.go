@@ -0,0 +1,20 @@
+// Package envvar implements `mactl env-var`'s subcommands.
+package envvar
+
+import (
+	"github.com/leftbin/mactl/internal/manifest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var Add = &cobra.Command{
+	Use:   "add <name> <value>",
+	Short: "export an env var from your shell rc file",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := manifest.SetEnvVar(args[0], args[1]); err != nil {
+			return errors.Wrapf(err, "failed to add env var %s", args[0])
+		}
+		return nil
+	},
+}
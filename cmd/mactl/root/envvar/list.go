@@ -0,0 +1,32 @@
+package envvar
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/leftbin/mactl/internal/manifest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var List = &cobra.Command{
+	Use:   "list",
+	Short: "list env vars exported from your shell rc file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vars, err := manifest.EnvVars()
+		if err != nil {
+			return errors.Wrap(err, "failed to list env vars")
+		}
+
+		names := make([]string, 0, len(vars))
+		for name := range vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s=%s\n", name, vars[name])
+		}
+		return nil
+	},
+}
@@ -0,0 +1,21 @@
+package root
+
+import (
+	"github.com/leftbin/mactl/cmd/mactl/root/get"
+	"github.com/spf13/cobra"
+)
+
+var Get = &cobra.Command{
+	Use:   "get",
+	Short: "inspect current mactl-managed state",
+}
+
+func init() {
+	Get.AddCommand(
+		get.EnvVars,
+		get.GitConfig,
+		get.SshKeys,
+		get.BrewPackages,
+		get.Dock,
+	)
+}
@@ -0,0 +1,47 @@
+package get
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/leftbin/mactl/internal/output"
+	"github.com/leftbin/mactl/internal/preflight"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type brewPackageList []string
+
+func (l brewPackageList) Header() []string { return []string{"NAME"} }
+
+func (l brewPackageList) Rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, name := range l {
+		rows = append(rows, []string{name})
+	}
+	return rows
+}
+
+var BrewPackages = &cobra.Command{
+	Use:   "brew-packages",
+	Short: "list installed Homebrew formulae and casks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, err := exec.Command("brew", "list").Output()
+		if err != nil {
+			return errors.Wrap(err, "failed to list brew packages")
+		}
+
+		var pkgs brewPackageList
+		for _, name := range strings.Fields(string(out)) {
+			pkgs = append(pkgs, name)
+		}
+
+		format, _ := cmd.Flags().GetString("output")
+		return output.Print(cmd.OutOrStdout(), output.Format(format), pkgs)
+	},
+}
+
+func init() {
+	output.AddFlag(BrewPackages)
+	preflight.Guard(BrewPackages, preflight.RequireBrew())
+}
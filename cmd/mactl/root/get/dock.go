@@ -0,0 +1,60 @@
+package get
+
+import (
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/leftbin/mactl/internal/output"
+	"github.com/leftbin/mactl/internal/preflight"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// dockKeys are the com.apple.dock preferences this command reports on.
+// Other keys set with `mactl optimize dock` are not shown.
+var dockKeys = []string{"autohide", "tilesize", "orientation", "minimize-to-application"}
+
+type dockRow struct {
+	Key   string `yaml:"key" json:"key"`
+	Value string `yaml:"value" json:"value"`
+}
+
+type dockList []dockRow
+
+func (l dockList) Header() []string { return []string{"KEY", "VALUE"} }
+
+func (l dockList) Rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, r := range l {
+		rows = append(rows, []string{r.Key, r.Value})
+	}
+	return rows
+}
+
+var Dock = &cobra.Command{
+	Use:   "dock",
+	Short: "show current com.apple.dock preference values",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var prefs dockList
+		for _, key := range dockKeys {
+			out, err := exec.Command("defaults", "read", "com.apple.dock", key).Output()
+			if err != nil {
+				continue
+			}
+			prefs = append(prefs, dockRow{Key: key, Value: strings.TrimSpace(string(out))})
+		}
+		sort.Slice(prefs, func(i, j int) bool { return prefs[i].Key < prefs[j].Key })
+
+		format, _ := cmd.Flags().GetString("output")
+		if err := output.Print(cmd.OutOrStdout(), output.Format(format), prefs); err != nil {
+			return errors.Wrap(err, "failed to print dock preferences")
+		}
+		return nil
+	},
+}
+
+func init() {
+	output.AddFlag(Dock)
+	preflight.Guard(Dock, preflight.RequireDarwin())
+}
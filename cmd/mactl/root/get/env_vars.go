@@ -0,0 +1,68 @@
+package get
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/leftbin/mactl/internal/output"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var exportRe = regexp.MustCompile(`^export\s+([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+
+type envVarRow struct {
+	Name  string `yaml:"name" json:"name"`
+	Value string `yaml:"value" json:"value"`
+}
+
+type envVarList []envVarRow
+
+func (l envVarList) Header() []string { return []string{"NAME", "VALUE"} }
+
+func (l envVarList) Rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, r := range l {
+		rows = append(rows, []string{r.Name, r.Value})
+	}
+	return rows
+}
+
+var EnvVars = &cobra.Command{
+	Use:   "env-vars",
+	Short: "list env vars exported from your shell rc file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return errors.Wrap(err, "failed to resolve home directory")
+		}
+
+		data, err := os.ReadFile(home + "/.zshrc")
+		if err != nil {
+			if os.IsNotExist(err) {
+				data = nil
+			} else {
+				return errors.Wrap(err, "failed to read shell rc file")
+			}
+		}
+
+		var vars envVarList
+		for _, line := range strings.Split(string(data), "\n") {
+			m := exportRe.FindStringSubmatch(strings.TrimSpace(line))
+			if m == nil {
+				continue
+			}
+			vars = append(vars, envVarRow{Name: m[1], Value: strings.Trim(m[2], `"`)})
+		}
+		sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+
+		format, _ := cmd.Flags().GetString("output")
+		return output.Print(cmd.OutOrStdout(), output.Format(format), vars)
+	},
+}
+
+func init() {
+	output.AddFlag(EnvVars)
+}
@@ -0,0 +1,59 @@
+package get
+
+import (
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/leftbin/mactl/internal/output"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type gitConfigRow struct {
+	Key   string `yaml:"key" json:"key"`
+	Value string `yaml:"value" json:"value"`
+}
+
+type gitConfigList []gitConfigRow
+
+func (l gitConfigList) Header() []string { return []string{"KEY", "VALUE"} }
+
+func (l gitConfigList) Rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, r := range l {
+		rows = append(rows, []string{r.Key, r.Value})
+	}
+	return rows
+}
+
+var GitConfig = &cobra.Command{
+	Use:   "git-config",
+	Short: "list the current global git config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, err := exec.Command("git", "config", "--global", "--list").Output()
+		if err != nil {
+			return errors.Wrap(err, "failed to list git config")
+		}
+
+		var entries gitConfigList
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			if line == "" {
+				continue
+			}
+			kv := strings.SplitN(line, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			entries = append(entries, gitConfigRow{Key: kv[0], Value: kv[1]})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+
+		format, _ := cmd.Flags().GetString("output")
+		return output.Print(cmd.OutOrStdout(), output.Format(format), entries)
+	},
+}
+
+func init() {
+	output.AddFlag(GitConfig)
+}
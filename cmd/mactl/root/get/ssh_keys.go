@@ -0,0 +1,81 @@
+package get
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/leftbin/mactl/internal/output"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type sshKeyRow struct {
+	Name string `yaml:"name" json:"name"`
+	Type string `yaml:"type" json:"type"`
+}
+
+type sshKeyList []sshKeyRow
+
+func (l sshKeyList) Header() []string { return []string{"NAME", "TYPE"} }
+
+func (l sshKeyList) Rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, r := range l {
+		rows = append(rows, []string{r.Name, r.Type})
+	}
+	return rows
+}
+
+var SshKeys = &cobra.Command{
+	Use:   "ssh-keys",
+	Short: "list SSH private keys under ~/.ssh",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return errors.Wrap(err, "failed to resolve home directory")
+		}
+
+		entries, err := os.ReadDir(filepath.Join(home, ".ssh"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				entries = nil
+			} else {
+				return errors.Wrap(err, "failed to list ~/.ssh")
+			}
+		}
+
+		var keys sshKeyList
+		for _, e := range entries {
+			if e.IsDir() || strings.HasSuffix(e.Name(), ".pub") || e.Name() == "config" || e.Name() == "known_hosts" {
+				continue
+			}
+			pub := filepath.Join(home, ".ssh", e.Name()+".pub")
+			if _, err := os.Stat(pub); err != nil {
+				continue
+			}
+			keys = append(keys, sshKeyRow{Name: e.Name(), Type: sshKeyType(pub)})
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
+		format, _ := cmd.Flags().GetString("output")
+		return output.Print(cmd.OutOrStdout(), output.Format(format), keys)
+	},
+}
+
+func sshKeyType(pubPath string) string {
+	data, err := os.ReadFile(pubPath)
+	if err != nil {
+		return "unknown"
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "unknown"
+	}
+	return strings.TrimPrefix(fields[0], "ssh-")
+}
+
+func init() {
+	output.AddFlag(SshKeys)
+}
@@ -2,6 +2,7 @@ package root
 
 import (
 	"github.com/leftbin/mactl/cmd/mactl/root/git"
+	"github.com/leftbin/mactl/internal/preflight"
 	"github.com/spf13/cobra"
 )
 
@@ -12,4 +13,5 @@ var Git = &cobra.Command{
 
 func init() {
 	Git.AddCommand(git.Config, git.Ssh)
+	preflight.Guard(Git, preflight.RequireCommand("git"), preflight.RequireCommand("ssh-keygen"))
 }
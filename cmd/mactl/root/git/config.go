@@ -0,0 +1,20 @@
+// Package git implements `mactl git`'s subcommands.
+package git
+
+import (
+	"github.com/leftbin/mactl/internal/manifest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var Config = &cobra.Command{
+	Use:   "config <key> <value>",
+	Short: "set a global git config value",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := manifest.SetGitConfigValue(args[0], args[1]); err != nil {
+			return errors.Wrapf(err, "failed to set git config %s", args[0])
+		}
+		return nil
+	},
+}
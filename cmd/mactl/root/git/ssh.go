@@ -0,0 +1,40 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/leftbin/mactl/internal/manifest"
+	"github.com/leftbin/mactl/internal/preflight"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var sshType string
+var sshComment string
+
+var Ssh = &cobra.Command{
+	Use:   "ssh <name>",
+	Short: "generate an SSH key under ~/.ssh",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return errors.Wrap(err, "failed to resolve home directory")
+		}
+		if err := preflight.Run(preflight.RequireWritablePath(filepath.Join(home, ".ssh"))); err != nil {
+			return errors.Wrap(err, "cannot generate ssh key")
+		}
+
+		key := manifest.SshKey{Name: args[0], Type: sshType, Comment: sshComment}
+		if err := manifest.EnsureSshKey(key); err != nil {
+			return errors.Wrapf(err, "failed to generate ssh key %s", args[0])
+		}
+		return nil
+	},
+}
+
+func init() {
+	Ssh.Flags().StringVar(&sshType, "type", "ed25519", "key type passed to ssh-keygen -t")
+	Ssh.Flags().StringVar(&sshComment, "comment", "", "comment passed to ssh-keygen -C")
+}
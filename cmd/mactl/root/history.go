@@ -0,0 +1,39 @@
+package root
+
+import (
+	"github.com/leftbin/mactl/internal/journal"
+	"github.com/leftbin/mactl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+type historyEntryList []journal.Entry
+
+func (l historyEntryList) Header() []string {
+	return []string{"TIMESTAMP", "KIND", "KEY", "PREVIOUS", "NEW"}
+}
+
+func (l historyEntryList) Rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, e := range l {
+		rows = append(rows, []string{e.Timestamp.Format("2006-01-02T15:04:05"), e.Kind, e.Key, e.Previous, e.New})
+	}
+	return rows
+}
+
+var History = &cobra.Command{
+	Use:   "history",
+	Short: "list the journal of changes mactl has made to this machine",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := journal.List()
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("output")
+		return output.Print(cmd.OutOrStdout(), output.Format(format), historyEntryList(entries))
+	},
+}
+
+func init() {
+	output.AddFlag(History)
+}
@@ -1,8 +1,9 @@
 package root
 
 import (
-	"github.com/spf13/cobra"
 	"github.com/leftbin/mactl/cmd/mactl/root/optimize"
+	"github.com/leftbin/mactl/internal/preflight"
+	"github.com/spf13/cobra"
 )
 
 var Optimize = &cobra.Command{
@@ -12,4 +13,5 @@ var Optimize = &cobra.Command{
 
 func init() {
 	Optimize.AddCommand(optimize.Dock)
+	preflight.Guard(Optimize, preflight.RequireDarwin())
 }
@@ -0,0 +1,20 @@
+// Package optimize implements `mactl optimize`'s subcommands.
+package optimize
+
+import (
+	"github.com/leftbin/mactl/internal/manifest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var Dock = &cobra.Command{
+	Use:   "dock <key> <value>",
+	Short: "set a com.apple.dock preference",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := manifest.SetDockPreference(args[0], args[1]); err != nil {
+			return errors.Wrapf(err, "failed to set dock preference %s", args[0])
+		}
+		return nil
+	},
+}
@@ -0,0 +1,19 @@
+package root
+
+import (
+	"github.com/leftbin/mactl/cmd/mactl/root/profile"
+	"github.com/spf13/cobra"
+)
+
+var Profile = &cobra.Command{
+	Use:   "profile",
+	Short: "snapshot and restore a full machine profile",
+}
+
+func init() {
+	Profile.AddCommand(
+		profile.Snapshot,
+		profile.Apply,
+		profile.Diff,
+	)
+}
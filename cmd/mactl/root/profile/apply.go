@@ -0,0 +1,28 @@
+package profile
+
+import (
+	"github.com/leftbin/mactl/internal/preflight"
+	"github.com/leftbin/mactl/internal/profile"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var applyDryRun bool
+
+var Apply = &cobra.Command{
+	Use:   "apply <file>",
+	Short: "restore a machine to match a profile lockfile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lf, err := profile.Load(args[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to load lockfile")
+		}
+		return profile.Apply(lf, applyDryRun)
+	},
+}
+
+func init() {
+	Apply.Flags().BoolVar(&applyDryRun, "dry-run", false, "print the actions that would be taken without executing them")
+	preflight.Guard(Apply, preflight.RequireDarwin(), preflight.RequireBrew(), preflight.RequireXcodeCLT())
+}
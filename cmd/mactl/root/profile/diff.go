@@ -0,0 +1,35 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/leftbin/mactl/internal/profile"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var Diff = &cobra.Command{
+	Use:   "diff <file>",
+	Short: "show drift between a profile lockfile and the current machine",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lf, err := profile.Load(args[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to load lockfile")
+		}
+
+		diffs, err := profile.Compare(lf)
+		if err != nil {
+			return errors.Wrap(err, "failed to compare lockfile against the current machine")
+		}
+
+		if len(diffs) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "no drift detected")
+			return nil
+		}
+		for _, d := range diffs {
+			fmt.Fprintln(cmd.OutOrStdout(), d.String())
+		}
+		return nil
+	},
+}
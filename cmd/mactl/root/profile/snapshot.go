@@ -0,0 +1,25 @@
+package profile
+
+import (
+	"github.com/leftbin/mactl/internal/profile"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var snapshotFile string
+
+var Snapshot = &cobra.Command{
+	Use:   "snapshot",
+	Short: "write the current machine's state to a lockfile",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lf, err := profile.Snapshot()
+		if err != nil {
+			return errors.Wrap(err, "failed to snapshot machine")
+		}
+		return profile.Save(lf, snapshotFile)
+	},
+}
+
+func init() {
+	Snapshot.Flags().StringVarP(&snapshotFile, "file", "f", "mactl.lock.yaml", "path to write the lockfile to")
+}
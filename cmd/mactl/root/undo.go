@@ -0,0 +1,15 @@
+package root
+
+import (
+	"github.com/leftbin/mactl/internal/journal"
+	_ "github.com/leftbin/mactl/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+var Undo = &cobra.Command{
+	Use:   "undo",
+	Short: "revert the most recent mactl change",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return journal.Undo()
+	},
+}
@@ -1,18 +1,26 @@
 package kustomize
 
 import (
+	"github.com/leftbin/mactl/internal/installer"
+	_ "github.com/leftbin/mactl/internal/installer/brew"
+	"github.com/leftbin/mactl/internal/preflight"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"github.com/leftbin/mactl/internal/installer/brew"
 )
 
-const (
-	BrewPkg = "kustomize"
-)
+// Requirements declares the package(s) needed to make kustomize available,
+// and which installer.Backend provides them.
+var Requirements = []installer.Requirement{
+	{Backend: "brew", Pkg: "kustomize"},
+}
 
 func Setup() error {
+	if err := preflight.Run(preflight.RequireBrew()); err != nil {
+		return errors.Wrap(err, "cannot install kustomize")
+	}
+
 	log.Info("installing kustomize")
-	if err := brew.Install(BrewPkg); err != nil {
+	if err := installer.Install(Requirements...); err != nil {
 		return errors.Wrap(err, "failed to install kustomize")
 	}
 	log.Info("installed kustomize")
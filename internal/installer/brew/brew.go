@@ -0,0 +1,49 @@
+// Package brew is the Homebrew installer.Backend, and also exposes its
+// operations as package-level functions for callers that don't need the
+// full Backend abstraction.
+package brew
+
+import (
+	"os/exec"
+
+	"github.com/leftbin/mactl/internal/installer"
+	"github.com/pkg/errors"
+)
+
+// Name is the backend identifier used in installer.Requirement.Backend.
+const Name = "brew"
+
+func init() {
+	installer.Register(backend{})
+}
+
+type backend struct{}
+
+func (backend) Name() string { return Name }
+
+func (backend) Install(pkg string) error {
+	if err := exec.Command("brew", "install", pkg).Run(); err != nil {
+		return errors.Wrapf(err, "failed to brew install %s", pkg)
+	}
+	return nil
+}
+
+func (backend) IsInstalled(pkg string) bool {
+	return exec.Command("brew", "list", pkg).Run() == nil
+}
+
+func (backend) Uninstall(pkg string) error {
+	if err := exec.Command("brew", "uninstall", pkg).Run(); err != nil {
+		return errors.Wrapf(err, "failed to brew uninstall %s", pkg)
+	}
+	return nil
+}
+
+// Install installs pkg via Homebrew.
+func Install(pkg string) error { return backend{}.Install(pkg) }
+
+// IsInstalled reports whether pkg is already installed via Homebrew.
+func IsInstalled(pkg string) bool { return backend{}.IsInstalled(pkg) }
+
+// Uninstall removes pkg via Homebrew.
+func Uninstall(pkg string) error { return backend{}.Uninstall(pkg) }
@@ -0,0 +1,67 @@
+// Package installer defines the pluggable package-manager abstraction used
+// by mactl's tool installers. A Backend wraps a single package manager
+// (Homebrew, mas, mise, ...); tools declare what they need as a slice of
+// Requirement and let Install resolve each one to its registered backend.
+package installer
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Backend is a single package-manager integration.
+type Backend interface {
+	// Name is the identifier used in a Requirement's Backend field, e.g. "brew".
+	Name() string
+	Install(pkg string) error
+	IsInstalled(pkg string) bool
+	Uninstall(pkg string) error
+}
+
+// Requirement declares that Pkg (optionally pinned to Version) must be
+// installed via Backend.
+type Requirement struct {
+	Backend string
+	Pkg     string
+	Version string
+}
+
+var backends = map[string]Backend{}
+
+// Register makes a Backend available for Requirements to resolve against.
+// Backend implementations call this from an init() func.
+func Register(b Backend) {
+	backends[b.Name()] = b
+}
+
+// Get returns the registered backend for name.
+func Get(name string) (Backend, error) {
+	b, ok := backends[name]
+	if !ok {
+		return nil, errors.Errorf("no installer backend registered for %q", name)
+	}
+	return b, nil
+}
+
+// Install resolves each requirement to its backend and installs it, skipping
+// any package that backend already reports as installed.
+func Install(reqs ...Requirement) error {
+	for _, req := range reqs {
+		backend, err := Get(req.Backend)
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve requirement %s", req.Pkg)
+		}
+
+		pkg := req.Pkg
+		if req.Version != "" {
+			pkg = pkg + "@" + req.Version
+		}
+
+		if backend.IsInstalled(pkg) {
+			continue
+		}
+		if err := backend.Install(pkg); err != nil {
+			return errors.Wrapf(err, "failed to install %s via %s", pkg, req.Backend)
+		}
+	}
+	return nil
+}
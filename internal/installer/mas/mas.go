@@ -0,0 +1,55 @@
+// Package mas is the Mac App Store installer.Backend, backed by the `mas`
+// CLI (https://github.com/mas-cli/mas). Packages are referenced by their
+// App Store numeric id, e.g. "497799835" for Xcode.
+package mas
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/leftbin/mactl/internal/installer"
+	"github.com/pkg/errors"
+)
+
+// Name is the backend identifier used in installer.Requirement.Backend.
+const Name = "mas"
+
+func init() {
+	installer.Register(backend{})
+}
+
+type backend struct{}
+
+func (backend) Name() string { return Name }
+
+func (backend) Install(pkg string) error {
+	if err := exec.Command("mas", "install", pkg).Run(); err != nil {
+		return errors.Wrapf(err, "failed to mas install %s", pkg)
+	}
+	return nil
+}
+
+func (backend) IsInstalled(pkg string) bool {
+	out, err := exec.Command("mas", "list").Output()
+	if err != nil {
+		return false
+	}
+	return listHasID(string(out), pkg)
+}
+
+// listHasID reports whether id appears as the leading field of a row in
+// mas list's "<id> <name> (<version>)" output, so a shorter id can't match
+// as a substring of a longer, unrelated one.
+func listHasID(list, id string) bool {
+	for _, line := range strings.Split(list, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (backend) Uninstall(pkg string) error {
+	return errors.New("mas does not support uninstalling apps, remove it from Launchpad instead")
+}
@@ -0,0 +1,17 @@
+package mas
+
+import "testing"
+
+func TestListHasID(t *testing.T) {
+	list := "497799835 Xcode (15.2)\n409183694 Keynote (13.2)\n"
+
+	if !listHasID(list, "497799835") {
+		t.Error("listHasID(497799835) = false, want true")
+	}
+	if listHasID(list, "0918369") {
+		t.Error("listHasID(0918369) = true, want false (must not substring-match within 409183694)")
+	}
+	if listHasID(list, "99999999") {
+		t.Error("listHasID(99999999) = true, want false")
+	}
+}
@@ -0,0 +1,68 @@
+// Package mise is the language-runtime installer.Backend, backed by the
+// mise CLI (https://mise.jdx.dev, the asdf successor). Packages are
+// runtime names such as "node" or "python"; a Requirement's Version pins
+// the runtime version mise installs.
+package mise
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/leftbin/mactl/internal/installer"
+	"github.com/pkg/errors"
+)
+
+// Name is the backend identifier used in installer.Requirement.Backend.
+const Name = "mise"
+
+func init() {
+	installer.Register(backend{})
+}
+
+type backend struct{}
+
+func (backend) Name() string { return Name }
+
+func (backend) Install(pkg string) error {
+	if err := exec.Command("mise", "use", "--global", pkg).Run(); err != nil {
+		return errors.Wrapf(err, "failed to mise install %s", pkg)
+	}
+	return nil
+}
+
+func (backend) IsInstalled(pkg string) bool {
+	name, version := splitNameVersion(pkg)
+
+	out, err := exec.Command("mise", "ls", "--installed").Output()
+	if err != nil {
+		return false
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != name {
+			continue
+		}
+		if version == "" || fields[1] == version || strings.HasPrefix(fields[1], version+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitNameVersion splits a "name@version" package string, as built by
+// installer.Install, into its runtime name and pinned version. version is
+// empty when pkg has no "@version" suffix.
+func splitNameVersion(pkg string) (name, version string) {
+	if i := strings.LastIndex(pkg, "@"); i != -1 {
+		return pkg[:i], pkg[i+1:]
+	}
+	return pkg, ""
+}
+
+func (backend) Uninstall(pkg string) error {
+	if err := exec.Command("mise", "uninstall", pkg).Run(); err != nil {
+		return errors.Wrapf(err, "failed to mise uninstall %s", pkg)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package mise
+
+import "testing"
+
+func TestSplitNameVersion(t *testing.T) {
+	cases := []struct {
+		pkg         string
+		name, value string
+	}{
+		{"node", "node", ""},
+		{"node@18", "node", "18"},
+		{"node@18.20.1", "node", "18.20.1"},
+	}
+
+	for _, c := range cases {
+		name, version := splitNameVersion(c.pkg)
+		if name != c.name || version != c.value {
+			t.Errorf("splitNameVersion(%q) = (%q, %q), want (%q, %q)", c.pkg, name, version, c.name, c.value)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package journal records every mutation mactl makes to the machine so it
+// can be listed with `mactl history` and rolled back with `mactl undo`.
+// Entries are appended as JSON lines to ~/.mactl/history.jsonl.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Entry is a single reversible change mactl made to the machine.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Kind      string    `json:"kind"`
+	Key       string    `json:"key"`
+	Previous  string    `json:"previous"`
+	New       string    `json:"new"`
+}
+
+// Reversible inverts a journal Entry, restoring Previous in place of New.
+// Mutating commands register one per Kind via RegisterInverse.
+type Reversible interface {
+	Undo(Entry) error
+}
+
+var inverses = map[string]Reversible{}
+
+// RegisterInverse makes the inverse handler for kind available to Undo.
+// Mutating packages call this from an init() func.
+func RegisterInverse(kind string, r Reversible) {
+	inverses[kind] = r
+}
+
+func path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve home directory")
+	}
+	return filepath.Join(home, ".mactl", "history.jsonl"), nil
+}
+
+// Record appends e to the journal, stamping Timestamp if it is zero.
+func Record(e Entry) error {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return errors.Wrap(err, "failed to create journal directory")
+	}
+
+	f, err := os.OpenFile(p, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed to open journal file")
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal journal entry")
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return errors.Wrap(err, "failed to append journal entry")
+	}
+	return nil
+}
+
+// List returns every recorded entry, oldest first.
+func List() ([]Entry, error) {
+	p, err := path()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to open journal file")
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, errors.Wrap(err, "failed to parse journal entry")
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read journal file")
+	}
+	return entries, nil
+}
+
+// last returns the most recent entry without removing it from the journal.
+func last() (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("journal is empty, nothing to undo")
+	}
+	e := entries[len(entries)-1]
+	return &e, nil
+}
+
+// pop removes and returns the most recent entry, rewriting the journal
+// without it.
+func pop() (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("journal is empty, nothing to undo")
+	}
+
+	last := entries[len(entries)-1]
+	remaining := entries[:len(entries)-1]
+
+	p, err := path()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(p, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to truncate journal file")
+	}
+	defer f.Close()
+
+	for _, e := range remaining {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal journal entry")
+		}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			return nil, errors.Wrap(err, "failed to rewrite journal file")
+		}
+	}
+	return &last, nil
+}
+
+// Undo invokes the registered inverse handler for the most recent entry,
+// and only then removes it from the journal, so a failing inverse leaves
+// the entry in place for `mactl history` and a later retry of `mactl undo`.
+func Undo() error {
+	entry, err := last()
+	if err != nil {
+		return err
+	}
+
+	inverse, ok := inverses[entry.Kind]
+	if !ok {
+		return errors.Errorf("no inverse handler registered for %q, cannot undo", entry.Kind)
+	}
+	if err := inverse.Undo(*entry); err != nil {
+		return errors.Wrapf(err, "failed to undo %s", entry.Kind)
+	}
+
+	if _, err := pop(); err != nil {
+		return errors.Wrap(err, "undo succeeded but failed to remove the journal entry")
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package journal
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type fakeReversible struct {
+	undone []Entry
+	err    error
+}
+
+func (f *fakeReversible) Undo(e Entry) error {
+	f.undone = append(f.undone, e)
+	return f.err
+}
+
+func TestRecordAndList(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	want := []Entry{
+		{Kind: "test.a", Key: "k1", Previous: "old", New: "new"},
+		{Kind: "test.a", Key: "k2", Previous: "", New: "new2"},
+	}
+	for _, e := range want {
+		if err := Record(e); err != nil {
+			t.Fatalf("Record(%+v): %v", e, err)
+		}
+	}
+
+	got, err := List()
+	if err != nil {
+		t.Fatalf("List(): %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("List() = %d entries, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		if e.Kind != want[i].Kind || e.Key != want[i].Key || e.Previous != want[i].Previous || e.New != want[i].New {
+			t.Errorf("List()[%d] = %+v, want %+v", i, e, want[i])
+		}
+		if e.Timestamp.IsZero() {
+			t.Errorf("List()[%d].Timestamp was not stamped", i)
+		}
+	}
+}
+
+func TestListWithNoJournalYet(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List(): %v", err)
+	}
+	if entries != nil {
+		t.Errorf("List() = %+v, want nil", entries)
+	}
+}
+
+func TestUndoPopsMostRecentAndInvokesInverse(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	fake := &fakeReversible{}
+	RegisterInverse("journal_test.kind", fake)
+
+	first := Entry{Kind: "journal_test.kind", Key: "k1", Previous: "a", New: "b"}
+	second := Entry{Kind: "journal_test.kind", Key: "k2", Previous: "c", New: "d"}
+	if err := Record(first); err != nil {
+		t.Fatalf("Record(first): %v", err)
+	}
+	if err := Record(second); err != nil {
+		t.Fatalf("Record(second): %v", err)
+	}
+
+	if err := Undo(); err != nil {
+		t.Fatalf("Undo(): %v", err)
+	}
+	if len(fake.undone) != 1 || fake.undone[0].Key != second.Key {
+		t.Fatalf("Undo() invoked inverse with %+v, want it invoked once with %+v", fake.undone, second)
+	}
+
+	remaining, err := List()
+	if err != nil {
+		t.Fatalf("List(): %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Key != first.Key {
+		t.Fatalf("List() after Undo = %+v, want only %+v left", remaining, first)
+	}
+}
+
+func TestUndoLeavesEntryInPlaceWhenInverseFails(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	fake := &fakeReversible{err: errors.New("brew uninstall failed")}
+	RegisterInverse("journal_test.failing-kind", fake)
+
+	entry := Entry{Kind: "journal_test.failing-kind", Key: "k1", Previous: "a", New: "b"}
+	if err := Record(entry); err != nil {
+		t.Fatalf("Record(): %v", err)
+	}
+
+	if err := Undo(); err == nil {
+		t.Fatal("Undo() with a failing inverse succeeded, want an error")
+	}
+
+	remaining, err := List()
+	if err != nil {
+		t.Fatalf("List(): %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Key != entry.Key {
+		t.Fatalf("List() after a failed Undo = %+v, want the original entry still present", remaining)
+	}
+}
+
+func TestUndoOnEmptyJournalFails(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := Undo(); err == nil {
+		t.Fatal("Undo() on an empty journal succeeded, want an error")
+	}
+}
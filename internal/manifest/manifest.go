@@ -0,0 +1,185 @@
+// Package manifest implements the declarative, GitOps-style desired-state
+// model used by `mactl apply`. A Config is parsed from a user-supplied YAML
+// file and reconciled against the current machine: brew sections are
+// installed through the installer package, and git, env var and dock
+// sections are applied by this package directly.
+package manifest
+
+import (
+	"io/ioutil"
+
+	"github.com/leftbin/mactl/internal/installer"
+	_ "github.com/leftbin/mactl/internal/installer/brew"
+	"github.com/leftbin/mactl/internal/journal"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the root of a mactl manifest.
+type Config struct {
+	Brew     BrewConfig        `yaml:"brew"`
+	Git      GitConfig         `yaml:"git"`
+	EnvVars  map[string]string `yaml:"envVars"`
+	Optimize OptimizeConfig    `yaml:"optimize"`
+}
+
+// BrewConfig describes the desired Homebrew formulae and casks.
+type BrewConfig struct {
+	Formulae []string `yaml:"formulae"`
+	Casks    []string `yaml:"casks"`
+}
+
+// GitConfig describes the desired git configuration and managed SSH keys.
+type GitConfig struct {
+	Config map[string]string `yaml:"config"`
+	Ssh    []SshKey          `yaml:"ssh"`
+}
+
+// SshKey describes an SSH key mactl should ensure exists.
+type SshKey struct {
+	Name    string `yaml:"name"`
+	Type    string `yaml:"type"`
+	Comment string `yaml:"comment"`
+}
+
+// OptimizeConfig describes desired macOS preference tweaks.
+type OptimizeConfig struct {
+	Dock map[string]string `yaml:"dock"`
+}
+
+// Action is a single reconciliation step, either reported in a dry-run diff
+// or executed against the machine.
+type Action struct {
+	Section string
+	Desc    string
+	Apply   func() error
+}
+
+// Load reads and parses a manifest file at path.
+func Load(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest file")
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to parse manifest file")
+	}
+	return &cfg, nil
+}
+
+// Plan builds the list of actions required to reconcile the machine to cfg.
+// Sections that are already satisfied (e.g. an already-installed formula or
+// an already-set git config value) are skipped, keeping Apply idempotent.
+func Plan(cfg *Config) ([]Action, error) {
+	var actions []Action
+
+	for _, formula := range cfg.Brew.Formulae {
+		req := installer.Requirement{Backend: "brew", Pkg: formula}
+		if isBrewPkgInstalled(formula) {
+			continue
+		}
+		actions = append(actions, Action{
+			Section: "brew",
+			Desc:    "install formula " + formula,
+			Apply:   func() error { return installBrewPkg(req) },
+		})
+	}
+	for _, cask := range cfg.Brew.Casks {
+		req := installer.Requirement{Backend: "brew", Pkg: cask}
+		if isBrewPkgInstalled(cask) {
+			continue
+		}
+		actions = append(actions, Action{
+			Section: "brew",
+			Desc:    "install cask " + cask,
+			Apply:   func() error { return installBrewPkg(req) },
+		})
+	}
+
+	for key, value := range cfg.Git.Config {
+		key, value := key, value
+		if current, err := gitConfigValue(key); err == nil && current == value {
+			continue
+		}
+		actions = append(actions, Action{
+			Section: "git",
+			Desc:    "set git config " + key + "=" + value,
+			Apply:   func() error { return SetGitConfigValue(key, value) },
+		})
+	}
+	for _, key := range cfg.Git.Ssh {
+		key := key
+		if isSshKeyPresent(key) {
+			continue
+		}
+		actions = append(actions, Action{
+			Section: "git",
+			Desc:    "ensure ssh key " + key.Name,
+			Apply:   func() error { return EnsureSshKey(key) },
+		})
+	}
+
+	for name, value := range cfg.EnvVars {
+		name, value := name, value
+		if IsEnvVarAlreadySet(name, value) {
+			continue
+		}
+		actions = append(actions, Action{
+			Section: "envVars",
+			Desc:    "set env var " + name + "=" + value,
+			Apply:   func() error { return SetEnvVar(name, value) },
+		})
+	}
+
+	for key, value := range cfg.Optimize.Dock {
+		key, value := key, value
+		if isDockPreferenceAlreadySet(key, value) {
+			continue
+		}
+		actions = append(actions, Action{
+			Section: "optimize.dock",
+			Desc:    "set dock." + key + "=" + value,
+			Apply:   func() error { return SetDockPreference(key, value) },
+		})
+	}
+
+	return actions, nil
+}
+
+// installBrewPkg installs req via the brew backend, journaling the install
+// so it can be reversed by `mactl undo`.
+func installBrewPkg(req installer.Requirement) error {
+	if err := installer.Install(req); err != nil {
+		return err
+	}
+	return journal.Record(journal.Entry{Kind: brewInstallKind, Key: req.Pkg, New: req.Pkg})
+}
+
+// Apply reconciles the machine to cfg. When dryRun is true, no action is
+// executed; the plan is only logged.
+func Apply(cfg *Config, dryRun bool) error {
+	actions, err := Plan(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to plan manifest")
+	}
+
+	if len(actions) == 0 {
+		log.Info("machine already matches manifest, nothing to do")
+		return nil
+	}
+
+	for _, action := range actions {
+		if dryRun {
+			log.Infof("[dry-run] %s: %s", action.Section, action.Desc)
+			continue
+		}
+		log.Infof("%s: %s", action.Section, action.Desc)
+		if err := action.Apply(); err != nil {
+			return errors.Wrapf(err, "failed to apply %s", action.Desc)
+		}
+	}
+	return nil
+}
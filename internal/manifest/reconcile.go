@@ -0,0 +1,282 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/leftbin/mactl/internal/journal"
+	"github.com/pkg/errors"
+)
+
+// isBrewPkgInstalled reports whether a formula or cask is already installed,
+// so Plan can skip it and stay idempotent.
+func isBrewPkgInstalled(pkg string) bool {
+	return exec.Command("brew", "list", pkg).Run() == nil
+}
+
+// gitConfigValue returns the current global git config value for key.
+func gitConfigValue(key string) (string, error) {
+	out, err := exec.Command("git", "config", "--global", key).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// applyGitConfigValue sets a global git config value without journaling it,
+// so both SetGitConfigValue and an undo can share it without recording a
+// fresh entry for the reversal itself.
+func applyGitConfigValue(key, value string) error {
+	if err := exec.Command("git", "config", "--global", key, value).Run(); err != nil {
+		return errors.Wrapf(err, "failed to set git config %s", key)
+	}
+	return nil
+}
+
+// SetGitConfigValue sets a global git config value, journaling the previous
+// value so it can be restored by `mactl undo`.
+func SetGitConfigValue(key, value string) error {
+	previous, _ := gitConfigValue(key)
+
+	if err := applyGitConfigValue(key, value); err != nil {
+		return err
+	}
+	return journal.Record(journal.Entry{Kind: gitConfigKind, Key: key, Previous: previous, New: value})
+}
+
+// isSshKeyPresent reports whether an SSH key matching key.Name already
+// exists under ~/.ssh, so Plan can skip it and stay idempotent.
+func isSshKeyPresent(key SshKey) bool {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(fmt.Sprintf("%s/.ssh/%s", home, key.Name))
+	return err == nil
+}
+
+// EnsureSshKey generates an SSH key under ~/.ssh if one matching name does
+// not already exist.
+func EnsureSshKey(key SshKey) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve home directory")
+	}
+	path := fmt.Sprintf("%s/.ssh/%s", home, key.Name)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrap(err, "failed to create ~/.ssh")
+	}
+
+	keyType := key.Type
+	if keyType == "" {
+		keyType = "ed25519"
+	}
+	args := []string{"-t", keyType, "-f", path, "-N", ""}
+	if key.Comment != "" {
+		args = append(args, "-C", key.Comment)
+	}
+	if err := exec.Command("ssh-keygen", args...).Run(); err != nil {
+		return errors.Wrapf(err, "failed to generate ssh key %s", key.Name)
+	}
+	return journal.Record(journal.Entry{Kind: gitSshKind, Key: key.Name, New: path})
+}
+
+// envRcPath returns the shell rc file mactl manages env vars in.
+func envRcPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve home directory")
+	}
+	return filepath.Join(home, ".zshrc"), nil
+}
+
+// ApplyEnvVar replaces any existing `export name=...` line in the shell rc
+// file with name=value, without journaling it.
+func ApplyEnvVar(name, value string) error {
+	rcPath, err := envRcPath()
+	if err != nil {
+		return err
+	}
+	if err := removeEnvVarLine(rcPath, name); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(rcPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed to open shell rc file")
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(fmt.Sprintf("export %s=%q\n", name, value)); err != nil {
+		return errors.Wrap(err, "failed to append env var to shell rc file")
+	}
+	return nil
+}
+
+// unsetEnvVar removes name's `export` line from the shell rc file entirely.
+func unsetEnvVar(name string) error {
+	rcPath, err := envRcPath()
+	if err != nil {
+		return err
+	}
+	return removeEnvVarLine(rcPath, name)
+}
+
+// removeEnvVarLine strips any `export name=...` line from rcPath. It is a
+// no-op if rcPath doesn't exist or has no such line.
+func removeEnvVarLine(rcPath, name string) error {
+	data, err := os.ReadFile(rcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to read shell rc file")
+	}
+
+	re := regexp.MustCompile(`^export\s+` + regexp.QuoteMeta(name) + `=.*$`)
+	lines := strings.Split(string(data), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if re.MatchString(strings.TrimSpace(line)) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	info, err := os.Stat(rcPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to stat shell rc file")
+	}
+	if err := os.WriteFile(rcPath, []byte(strings.Join(kept, "\n")), info.Mode()); err != nil {
+		return errors.Wrap(err, "failed to rewrite shell rc file")
+	}
+	return nil
+}
+
+// SetEnvVar persists name=value by exporting it from the user's shell rc
+// file, matching what `mactl env-var add` does today, and journals the
+// previous value so it can be restored by `mactl undo`.
+func SetEnvVar(name, value string) error {
+	rcPath, err := envRcPath()
+	if err != nil {
+		return err
+	}
+	previous, _ := currentEnvVar(rcPath, name)
+
+	if err := ApplyEnvVar(name, value); err != nil {
+		return err
+	}
+	return journal.Record(journal.Entry{Kind: envVarKind, Key: name, Previous: previous, New: value})
+}
+
+// currentEnvVar returns the value name is currently exported to in rcPath,
+// and whether it is set there at all.
+func currentEnvVar(rcPath, name string) (string, bool) {
+	data, err := os.ReadFile(rcPath)
+	if err != nil {
+		return "", false
+	}
+	re := regexp.MustCompile(`^export\s+` + regexp.QuoteMeta(name) + `=(.*)$`)
+	for _, line := range strings.Split(string(data), "\n") {
+		if m := re.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			return strings.Trim(m[1], `"`), true
+		}
+	}
+	return "", false
+}
+
+// EnvVars returns every name=value pair currently exported from the user's
+// shell rc file.
+func EnvVars() (map[string]string, error) {
+	rcPath, err := envRcPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(rcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, errors.Wrap(err, "failed to read shell rc file")
+	}
+
+	re := regexp.MustCompile(`^export\s+([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+	vars := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if m := re.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			vars[m[1]] = strings.Trim(m[2], `"`)
+		}
+	}
+	return vars, nil
+}
+
+// IsEnvVarAlreadySet reports whether name is already exported to value in
+// the user's shell rc file, so Plan can skip it and stay idempotent.
+func IsEnvVarAlreadySet(name, value string) bool {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+	current, ok := currentEnvVar(filepath.Join(home, ".zshrc"), name)
+	return ok && current == value
+}
+
+// dockPreferenceValue returns the current `defaults read com.apple.dock`
+// value for key.
+func dockPreferenceValue(key string) (string, error) {
+	out, err := exec.Command("defaults", "read", "com.apple.dock", key).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// isDockPreferenceAlreadySet reports whether key is already set to value,
+// so Plan can skip it and stay idempotent.
+func isDockPreferenceAlreadySet(key, value string) bool {
+	current, err := dockPreferenceValue(key)
+	return err == nil && current == value
+}
+
+// applyDockPreference writes a single com.apple.dock key and restarts Dock,
+// without journaling it.
+func applyDockPreference(key, value string) error {
+	if err := exec.Command("defaults", "write", "com.apple.dock", key, value).Run(); err != nil {
+		return errors.Wrapf(err, "failed to set dock preference %s", key)
+	}
+	return restartDock()
+}
+
+// resetDockPreference deletes a com.apple.dock key, restoring the system
+// default, and restarts Dock.
+func resetDockPreference(key string) error {
+	if err := exec.Command("defaults", "delete", "com.apple.dock", key).Run(); err != nil {
+		return errors.Wrapf(err, "failed to reset dock preference %s", key)
+	}
+	return restartDock()
+}
+
+func restartDock() error {
+	if err := exec.Command("killall", "Dock").Run(); err != nil {
+		return errors.Wrap(err, "failed to restart Dock")
+	}
+	return nil
+}
+
+// SetDockPreference applies a single `defaults write com.apple.dock` key,
+// journaling the previous value so it can be restored by `mactl undo`.
+func SetDockPreference(key, value string) error {
+	previous, _ := dockPreferenceValue(key)
+
+	if err := applyDockPreference(key, value); err != nil {
+		return err
+	}
+	return journal.Record(journal.Entry{Kind: optimizeDockKind, Key: key, Previous: previous, New: value})
+}
@@ -0,0 +1,109 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeZshrc(t *testing.T, contents string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, ".zshrc"), []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write .zshrc fixture: %v", err)
+	}
+}
+
+func TestCurrentEnvVar(t *testing.T) {
+	writeZshrc(t, "export FOO=\"bar\"\nexport BAZ=qux\n")
+	rcPath, err := envRcPath()
+	if err != nil {
+		t.Fatalf("envRcPath(): %v", err)
+	}
+
+	if value, ok := currentEnvVar(rcPath, "FOO"); !ok || value != "bar" {
+		t.Errorf("currentEnvVar(FOO) = (%q, %v), want (\"bar\", true)", value, ok)
+	}
+	if value, ok := currentEnvVar(rcPath, "BAZ"); !ok || value != "qux" {
+		t.Errorf("currentEnvVar(BAZ) = (%q, %v), want (\"qux\", true)", value, ok)
+	}
+	if _, ok := currentEnvVar(rcPath, "MISSING"); ok {
+		t.Error("currentEnvVar(MISSING) reported set, want not set")
+	}
+}
+
+func TestCurrentEnvVarNoRcFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	rcPath, err := envRcPath()
+	if err != nil {
+		t.Fatalf("envRcPath(): %v", err)
+	}
+
+	if _, ok := currentEnvVar(rcPath, "FOO"); ok {
+		t.Error("currentEnvVar on a missing rc file reported set, want not set")
+	}
+}
+
+// TestIsEnvVarAlreadySetIdempotency guards Plan's idempotency: it must skip
+// an env var that is already exported to the desired value, or `mactl
+// apply` would rewrite its export line in ~/.zshrc and journal a no-op
+// change on every re-run.
+func TestIsEnvVarAlreadySetIdempotency(t *testing.T) {
+	writeZshrc(t, "export EDITOR=vim\n")
+
+	if !IsEnvVarAlreadySet("EDITOR", "vim") {
+		t.Error("IsEnvVarAlreadySet(EDITOR, vim) = false, want true")
+	}
+	if IsEnvVarAlreadySet("EDITOR", "nano") {
+		t.Error("IsEnvVarAlreadySet(EDITOR, nano) = true, want false")
+	}
+	if IsEnvVarAlreadySet("UNSET", "vim") {
+		t.Error("IsEnvVarAlreadySet(UNSET, vim) = true, want false")
+	}
+}
+
+func TestEnvVars(t *testing.T) {
+	writeZshrc(t, "export FOO=\"bar\"\nexport BAZ=qux\nnot an export line\n")
+
+	vars, err := EnvVars()
+	if err != nil {
+		t.Fatalf("EnvVars(): %v", err)
+	}
+	want := map[string]string{"FOO": "bar", "BAZ": "qux"}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("EnvVars() = %+v, want %+v", vars, want)
+	}
+}
+
+func TestEnvVarsNoRcFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	vars, err := EnvVars()
+	if err != nil {
+		t.Fatalf("EnvVars(): %v", err)
+	}
+	if len(vars) != 0 {
+		t.Errorf("EnvVars() = %+v, want empty", vars)
+	}
+}
+
+func TestRemoveEnvVarLine(t *testing.T) {
+	writeZshrc(t, "export KEEP=1\nexport DROP=2\n")
+	rcPath, err := envRcPath()
+	if err != nil {
+		t.Fatalf("envRcPath(): %v", err)
+	}
+
+	if err := removeEnvVarLine(rcPath, "DROP"); err != nil {
+		t.Fatalf("removeEnvVarLine(DROP): %v", err)
+	}
+
+	if _, ok := currentEnvVar(rcPath, "DROP"); ok {
+		t.Error("DROP still present after removeEnvVarLine")
+	}
+	if value, ok := currentEnvVar(rcPath, "KEEP"); !ok || value != "1" {
+		t.Errorf("KEEP = (%q, %v), want (\"1\", true)", value, ok)
+	}
+}
@@ -0,0 +1,77 @@
+package manifest
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/leftbin/mactl/internal/installer"
+	"github.com/leftbin/mactl/internal/journal"
+	"github.com/pkg/errors"
+)
+
+// Journal kinds recorded by the reconcile actions in this package.
+const (
+	gitConfigKind    = "git.config"
+	gitSshKind       = "git.ssh"
+	envVarKind       = "env.var"
+	optimizeDockKind = "optimize.dock"
+	brewInstallKind  = "brew.install"
+)
+
+func init() {
+	journal.RegisterInverse(gitConfigKind, reverseGitConfig{})
+	journal.RegisterInverse(gitSshKind, reverseGitSsh{})
+	journal.RegisterInverse(envVarKind, reverseEnvVar{})
+	journal.RegisterInverse(optimizeDockKind, reverseOptimizeDock{})
+	journal.RegisterInverse(brewInstallKind, reverseBrewInstall{})
+}
+
+type reverseGitConfig struct{}
+
+func (reverseGitConfig) Undo(e journal.Entry) error {
+	if e.Previous == "" {
+		return exec.Command("git", "config", "--global", "--unset", e.Key).Run()
+	}
+	return applyGitConfigValue(e.Key, e.Previous)
+}
+
+type reverseGitSsh struct{}
+
+func (reverseGitSsh) Undo(e journal.Entry) error {
+	if err := os.Remove(e.New); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove ssh key %s", e.New)
+	}
+	pub := e.New + ".pub"
+	if err := os.Remove(pub); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove ssh public key %s", pub)
+	}
+	return nil
+}
+
+type reverseEnvVar struct{}
+
+func (reverseEnvVar) Undo(e journal.Entry) error {
+	if e.Previous == "" {
+		return unsetEnvVar(e.Key)
+	}
+	return ApplyEnvVar(e.Key, e.Previous)
+}
+
+type reverseOptimizeDock struct{}
+
+func (reverseOptimizeDock) Undo(e journal.Entry) error {
+	if e.Previous == "" {
+		return resetDockPreference(e.Key)
+	}
+	return applyDockPreference(e.Key, e.Previous)
+}
+
+type reverseBrewInstall struct{}
+
+func (reverseBrewInstall) Undo(e journal.Entry) error {
+	backend, err := installer.Get("brew")
+	if err != nil {
+		return err
+	}
+	return backend.Uninstall(e.Key)
+}
@@ -0,0 +1,13 @@
+package output
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// AddFlag registers the shared -o/--output flag on cmd, defaulting to Table,
+// and returns the pointer it writes into.
+func AddFlag(cmd *cobra.Command) *string {
+	format := string(Table)
+	cmd.Flags().StringVarP(&format, "output", "o", string(Table), "output format: table|json|yaml")
+	return &format
+}
@@ -0,0 +1,61 @@
+// Package output renders command results in the table/json/yaml formats
+// shared by every `mactl get` subcommand.
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is one of the formats Print supports.
+type Format string
+
+const (
+	Table Format = "table"
+	JSON  Format = "json"
+	YAML  Format = "yaml"
+)
+
+// Tabular is implemented by data that knows how to render itself as a table.
+type Tabular interface {
+	Header() []string
+	Rows() [][]string
+}
+
+// Print writes data to w in the requested format. Table output requires
+// data to implement Tabular.
+func Print(w io.Writer, format Format, data interface{}) error {
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	case YAML:
+		enc := yaml.NewEncoder(w)
+		defer enc.Close()
+		return enc.Encode(data)
+	case Table, "":
+		t, ok := data.(Tabular)
+		if !ok {
+			return errors.Errorf("data of type %T does not support table output", data)
+		}
+		return printTable(w, t)
+	default:
+		return errors.Errorf("unsupported output format %q", format)
+	}
+}
+
+func printTable(w io.Writer, t Tabular) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.Header(), "\t"))
+	for _, row := range t.Rows() {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
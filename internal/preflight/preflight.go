@@ -0,0 +1,126 @@
+// Package preflight provides composable checks that guard mactl commands
+// from running in an environment they can't support, failing fast with a
+// clear message instead of a cryptic error partway through.
+package preflight
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// Check is a single named precondition.
+type Check struct {
+	Name string
+	Run  func() error
+}
+
+// RequireDarwin fails on any OS other than macOS.
+func RequireDarwin() Check {
+	return Check{
+		Name: "darwin",
+		Run: func() error {
+			if runtime.GOOS != "darwin" {
+				return errors.Errorf("this command only works on macOS, not %s", runtime.GOOS)
+			}
+			return nil
+		},
+	}
+}
+
+// RequireBrew fails if Homebrew is not on PATH.
+func RequireBrew() Check {
+	return Check{
+		Name: "brew",
+		Run: func() error {
+			return RequireCommand("brew").Run()
+		},
+	}
+}
+
+// RequireCommand fails if name is not on PATH.
+func RequireCommand(name string) Check {
+	return Check{
+		Name: name,
+		Run: func() error {
+			if _, err := exec.LookPath(name); err != nil {
+				return errors.Errorf("%s is required but was not found on PATH", name)
+			}
+			return nil
+		},
+	}
+}
+
+// RequireWritablePath fails if path is not a writable directory. If path
+// does not exist yet (e.g. it will be created on demand, like ~/.ssh on a
+// fresh machine), its nearest existing ancestor is checked for
+// writability instead.
+func RequireWritablePath(path string) Check {
+	return Check{
+		Name: "writable:" + path,
+		Run: func() error {
+			dir, err := nearestExistingDir(path)
+			if err != nil {
+				return errors.Wrapf(err, "%s is not accessible", path)
+			}
+
+			probe := filepath.Join(dir, ".mactl-write-check")
+			f, err := os.Create(probe)
+			if err != nil {
+				return errors.Wrapf(err, "%s is not writable", dir)
+			}
+			f.Close()
+			return os.Remove(probe)
+		},
+	}
+}
+
+// nearestExistingDir walks up from path until it finds a directory that
+// exists, so a not-yet-created path can still be checked for writability
+// via the ancestor that will actually receive the mkdir/create call.
+func nearestExistingDir(path string) (string, error) {
+	for {
+		info, err := os.Stat(path)
+		switch {
+		case err == nil:
+			if !info.IsDir() {
+				return "", errors.Errorf("%s is not a directory", path)
+			}
+			return path, nil
+		case !os.IsNotExist(err):
+			return "", err
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", errors.Errorf("no existing ancestor directory found")
+		}
+		path = parent
+	}
+}
+
+// RequireXcodeCLT fails if the Xcode Command Line Tools are not installed.
+func RequireXcodeCLT() Check {
+	return Check{
+		Name: "xcode-clt",
+		Run: func() error {
+			if err := exec.Command("xcode-select", "-p").Run(); err != nil {
+				return errors.New("Xcode Command Line Tools are required, install them with `xcode-select --install`")
+			}
+			return nil
+		},
+	}
+}
+
+// Run executes checks in order, returning the first failure.
+func Run(checks ...Check) error {
+	for _, check := range checks {
+		if err := check.Run(); err != nil {
+			return errors.Wrapf(err, "preflight check %q failed", check.Name)
+		}
+	}
+	return nil
+}
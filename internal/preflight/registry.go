@@ -0,0 +1,44 @@
+package preflight
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// registration ties a set of checks to the command they guard.
+type registration struct {
+	Command *cobra.Command
+	Checks  []Check
+}
+
+var registrations []registration
+
+// Guard wires checks as cmd's PersistentPreRunE and records them so
+// `mactl check` can report whether cmd is usable on this machine.
+func Guard(cmd *cobra.Command, checks ...Check) {
+	cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+		return Run(checks...)
+	}
+	registrations = append(registrations, registration{Command: cmd, Checks: checks})
+}
+
+// Result is the outcome of running one command's registered checks.
+type Result struct {
+	CommandPath string
+	Err         error
+}
+
+// Usable reports whether the command's preflight checks all passed.
+func (r Result) Usable() bool { return r.Err == nil }
+
+// CheckAll runs every registered command's preflight checks and reports the
+// outcome for each.
+func CheckAll() []Result {
+	results := make([]Result, 0, len(registrations))
+	for _, reg := range registrations {
+		results = append(results, Result{
+			CommandPath: reg.Command.CommandPath(),
+			Err:         Run(reg.Checks...),
+		})
+	}
+	return results
+}
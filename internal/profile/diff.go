@@ -0,0 +1,111 @@
+package profile
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Diff is a single line of drift between a Lockfile and the current state
+// of the machine.
+type Diff struct {
+	Section string
+	Message string
+}
+
+func (d Diff) String() string {
+	return fmt.Sprintf("%s: %s", d.Section, d.Message)
+}
+
+// Compare snapshots the current machine and reports how it has drifted
+// from lf.
+func Compare(lf *Lockfile) ([]Diff, error) {
+	current, err := Snapshot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to snapshot current machine")
+	}
+
+	var diffs []Diff
+	diffs = append(diffs, diffPackages("brew.formulae", lf.Brew.Formulae, current.Brew.Formulae)...)
+	diffs = append(diffs, diffPackages("brew.casks", lf.Brew.Casks, current.Brew.Casks)...)
+	diffs = append(diffs, diffStringMap("git.config", lf.Git.Config, current.Git.Config)...)
+	diffs = append(diffs, diffSshFingerprints(lf.Git.Ssh, current.Git.Ssh)...)
+	diffs = append(diffs, diffStringMap("envVars", lf.EnvVars, current.EnvVars)...)
+	diffs = append(diffs, diffStringMap("dock", lf.Dock, current.Dock)...)
+	return diffs, nil
+}
+
+func diffPackages(section string, want, have []PinnedPackage) []Diff {
+	wantByName := map[string]string{}
+	for _, p := range want {
+		wantByName[p.Name] = p.Version
+	}
+	haveByName := map[string]string{}
+	for _, p := range have {
+		haveByName[p.Name] = p.Version
+	}
+
+	var diffs []Diff
+	for name, version := range wantByName {
+		current, ok := haveByName[name]
+		switch {
+		case !ok:
+			diffs = append(diffs, Diff{Section: section, Message: fmt.Sprintf("%s is missing (want %s)", name, versionLabel(version))})
+		case version != "" && current != version:
+			diffs = append(diffs, Diff{Section: section, Message: fmt.Sprintf("%s is %s, want %s", name, current, version)})
+		}
+	}
+	for name := range haveByName {
+		if _, ok := wantByName[name]; !ok {
+			diffs = append(diffs, Diff{Section: section, Message: fmt.Sprintf("%s is installed but not in the lockfile", name)})
+		}
+	}
+	return sortedDiffs(diffs)
+}
+
+func diffStringMap(section string, want, have map[string]string) []Diff {
+	var diffs []Diff
+	for key, value := range want {
+		current, ok := have[key]
+		switch {
+		case !ok:
+			diffs = append(diffs, Diff{Section: section, Message: fmt.Sprintf("%s is unset (want %s)", key, value)})
+		case current != value:
+			diffs = append(diffs, Diff{Section: section, Message: fmt.Sprintf("%s is %s, want %s", key, current, value)})
+		}
+	}
+	for key := range have {
+		if _, ok := want[key]; !ok {
+			diffs = append(diffs, Diff{Section: section, Message: fmt.Sprintf("%s is set but not in the lockfile", key)})
+		}
+	}
+	return sortedDiffs(diffs)
+}
+
+func diffSshFingerprints(want, have []SshFingerprint) []Diff {
+	haveByFingerprint := map[string]bool{}
+	for _, k := range have {
+		haveByFingerprint[k.Fingerprint] = true
+	}
+
+	var diffs []Diff
+	for _, k := range want {
+		if !haveByFingerprint[k.Fingerprint] {
+			diffs = append(diffs, Diff{Section: "git.ssh", Message: fmt.Sprintf("%s (%s) is missing locally", k.Name, k.Fingerprint)})
+		}
+	}
+	return sortedDiffs(diffs)
+}
+
+func versionLabel(version string) string {
+	if version == "" {
+		return "any version"
+	}
+	return version
+}
+
+func sortedDiffs(diffs []Diff) []Diff {
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Message < diffs[j].Message })
+	return diffs
+}
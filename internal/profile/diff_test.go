@@ -0,0 +1,84 @@
+package profile
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffPackages(t *testing.T) {
+	want := []PinnedPackage{
+		{Name: "jq", Version: "1.7"},
+		{Name: "ripgrep", Version: "14.0"},
+		{Name: "unpinned"},
+	}
+	have := []PinnedPackage{
+		{Name: "jq", Version: "1.6"},
+		{Name: "unpinned", Version: "1.0"},
+		{Name: "extra", Version: "2.0"},
+	}
+
+	got := diffPackages("brew.formulae", want, have)
+	wantDiffs := []Diff{
+		{Section: "brew.formulae", Message: "extra is installed but not in the lockfile"},
+		{Section: "brew.formulae", Message: "jq is 1.6, want 1.7"},
+		{Section: "brew.formulae", Message: "ripgrep is missing (want 14.0)"},
+	}
+	if !reflect.DeepEqual(got, wantDiffs) {
+		t.Errorf("diffPackages() = %+v, want %+v", got, wantDiffs)
+	}
+}
+
+func TestDiffPackagesNoDrift(t *testing.T) {
+	pkgs := []PinnedPackage{{Name: "jq", Version: "1.7"}}
+	if got := diffPackages("brew.formulae", pkgs, pkgs); len(got) != 0 {
+		t.Errorf("diffPackages() = %+v, want no diffs", got)
+	}
+}
+
+func TestDiffPackagesUnpinnedAlwaysMatches(t *testing.T) {
+	want := []PinnedPackage{{Name: "jq"}}
+	have := []PinnedPackage{{Name: "jq", Version: "1.7"}}
+	if got := diffPackages("brew.formulae", want, have); len(got) != 0 {
+		t.Errorf("diffPackages() = %+v, want no diffs for an unpinned requirement", got)
+	}
+}
+
+func TestDiffStringMap(t *testing.T) {
+	want := map[string]string{"user.name": "Ada", "user.email": "ada@example.com"}
+	have := map[string]string{"user.name": "Bob", "core.editor": "vim"}
+
+	got := diffStringMap("git.config", want, have)
+	wantDiffs := []Diff{
+		{Section: "git.config", Message: "core.editor is set but not in the lockfile"},
+		{Section: "git.config", Message: "user.email is unset (want ada@example.com)"},
+		{Section: "git.config", Message: "user.name is Bob, want Ada"},
+	}
+	if !reflect.DeepEqual(got, wantDiffs) {
+		t.Errorf("diffStringMap() = %+v, want %+v", got, wantDiffs)
+	}
+}
+
+func TestDiffStringMapNoDrift(t *testing.T) {
+	m := map[string]string{"user.name": "Ada"}
+	if got := diffStringMap("git.config", m, m); len(got) != 0 {
+		t.Errorf("diffStringMap() = %+v, want no diffs", got)
+	}
+}
+
+func TestDiffSshFingerprints(t *testing.T) {
+	want := []SshFingerprint{
+		{Name: "work", Fingerprint: "SHA256:aaa"},
+		{Name: "personal", Fingerprint: "SHA256:bbb"},
+	}
+	have := []SshFingerprint{
+		{Name: "work", Fingerprint: "SHA256:aaa"},
+	}
+
+	got := diffSshFingerprints(want, have)
+	wantDiffs := []Diff{
+		{Section: "git.ssh", Message: "personal (SHA256:bbb) is missing locally"},
+	}
+	if !reflect.DeepEqual(got, wantDiffs) {
+		t.Errorf("diffSshFingerprints() = %+v, want %+v", got, wantDiffs)
+	}
+}
@@ -0,0 +1,237 @@
+// Package profile snapshots and restores the full state of a Mac - pinned
+// brew formulae/casks, git config, SSH key fingerprints, shell env vars and
+// dock layout - as a mactl.lock.yaml file, so one machine can be reproduced
+// on another.
+package profile
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Lockfile is the full machine snapshot written to mactl.lock.yaml.
+type Lockfile struct {
+	Brew    BrewLock          `yaml:"brew"`
+	Git     GitLock           `yaml:"git"`
+	EnvVars map[string]string `yaml:"envVars"`
+	Dock    map[string]string `yaml:"dock"`
+}
+
+// BrewLock pins the exact version of every installed formula and cask.
+type BrewLock struct {
+	Formulae []PinnedPackage `yaml:"formulae"`
+	Casks    []PinnedPackage `yaml:"casks"`
+}
+
+// PinnedPackage is a brew package pinned to the version installed when the
+// snapshot was taken.
+type PinnedPackage struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version,omitempty"`
+}
+
+// GitLock captures global git config and the fingerprints of managed SSH
+// keys, so profile diff can detect a key being replaced without storing the
+// private key material itself.
+type GitLock struct {
+	Config map[string]string `yaml:"config"`
+	Ssh    []SshFingerprint  `yaml:"ssh"`
+}
+
+// SshFingerprint identifies an SSH key by its public fingerprint.
+type SshFingerprint struct {
+	Name        string `yaml:"name"`
+	Fingerprint string `yaml:"fingerprint"`
+}
+
+var dockKeys = []string{"autohide", "tilesize", "orientation", "minimize-to-application"}
+
+// Snapshot captures the current state of this machine.
+func Snapshot() (*Lockfile, error) {
+	formulae, casks, err := snapshotBrew()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to snapshot brew packages")
+	}
+
+	gitConfig, err := snapshotGitConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to snapshot git config")
+	}
+
+	sshKeys, err := snapshotSshKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to snapshot ssh keys")
+	}
+
+	envVars, err := snapshotEnvVars()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to snapshot env vars")
+	}
+
+	return &Lockfile{
+		Brew:    BrewLock{Formulae: formulae, Casks: casks},
+		Git:     GitLock{Config: gitConfig, Ssh: sshKeys},
+		EnvVars: envVars,
+		Dock:    snapshotDock(),
+	}, nil
+}
+
+// Load reads a Lockfile from path.
+func Load(path string) (*Lockfile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read lockfile")
+	}
+	var lf Lockfile
+	if err := yaml.Unmarshal(data, &lf); err != nil {
+		return nil, errors.Wrap(err, "failed to parse lockfile")
+	}
+	return &lf, nil
+}
+
+// Save writes lf to path as YAML.
+func Save(lf *Lockfile, path string) error {
+	data, err := yaml.Marshal(lf)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal lockfile")
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrap(err, "failed to write lockfile")
+	}
+	return nil
+}
+
+func snapshotBrew() ([]PinnedPackage, []PinnedPackage, error) {
+	formulae, err := brewListVersions(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	casks, err := brewListVersions(true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return formulae, casks, nil
+}
+
+func brewListVersions(casks bool) ([]PinnedPackage, error) {
+	args := []string{"list", "--versions"}
+	if casks {
+		args = append(args, "--cask")
+	}
+	out, err := exec.Command("brew", args...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []PinnedPackage
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		pkg := PinnedPackage{Name: fields[0]}
+		if len(fields) > 1 {
+			pkg.Version = fields[len(fields)-1]
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+func snapshotGitConfig() (map[string]string, error) {
+	out, err := exec.Command("git", "config", "--global", "--list").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		config[kv[0]] = kv[1]
+	}
+	return config, nil
+}
+
+func snapshotSshKeys() ([]SshFingerprint, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	sshDir := filepath.Join(home, ".ssh")
+	entries, err := os.ReadDir(sshDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var keys []SshFingerprint
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".pub") {
+			continue
+		}
+		pub := filepath.Join(sshDir, e.Name())
+		out, err := exec.Command("ssh-keygen", "-lf", pub).Output()
+		if err != nil {
+			continue
+		}
+		fields := strings.Fields(string(out))
+		if len(fields) < 2 {
+			continue
+		}
+		keys = append(keys, SshFingerprint{
+			Name:        strings.TrimSuffix(e.Name(), ".pub"),
+			Fingerprint: fields[1],
+		})
+	}
+	return keys, nil
+}
+
+var exportRe = regexp.MustCompile(`^export\s+([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+
+func snapshotEnvVars() (map[string]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(home, ".zshrc"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	vars := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if m := exportRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			vars[m[1]] = strings.Trim(m[2], `"`)
+		}
+	}
+	return vars, nil
+}
+
+func snapshotDock() map[string]string {
+	dock := map[string]string{}
+	for _, key := range dockKeys {
+		out, err := exec.Command("defaults", "read", "com.apple.dock", key).Output()
+		if err != nil {
+			continue
+		}
+		dock[key] = strings.TrimSpace(string(out))
+	}
+	return dock
+}
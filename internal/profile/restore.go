@@ -0,0 +1,125 @@
+package profile
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/leftbin/mactl/internal/installer"
+	_ "github.com/leftbin/mactl/internal/installer/brew"
+	"github.com/leftbin/mactl/internal/manifest"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Apply restores the machine to match lf. SSH keys are not restored - a
+// fingerprint cannot reproduce private key material - Apply only warns about
+// lockfile keys that are missing locally; extra local keys are not reported.
+func Apply(lf *Lockfile, dryRun bool) error {
+	for _, pkg := range lf.Brew.Formulae {
+		if err := applyBrewPkg(pkg, dryRun); err != nil {
+			return err
+		}
+	}
+	for _, pkg := range lf.Brew.Casks {
+		if err := applyBrewPkg(pkg, dryRun); err != nil {
+			return err
+		}
+	}
+
+	for key, value := range lf.Git.Config {
+		if current, err := gitConfigValue(key); err == nil && current == value {
+			continue
+		}
+		if dryRun {
+			log.Infof("[dry-run] git.config: set %s=%s", key, value)
+			continue
+		}
+		log.Infof("git.config: set %s=%s", key, value)
+		if err := exec.Command("git", "config", "--global", key, value).Run(); err != nil {
+			return errors.Wrapf(err, "failed to set git config %s", key)
+		}
+	}
+
+	for _, key := range lf.Git.Ssh {
+		if !localSshFingerprintExists(key.Fingerprint) {
+			log.Warnf("ssh key %s (%s) from the lockfile is missing locally and cannot be restored automatically", key.Name, key.Fingerprint)
+		}
+	}
+
+	for name, value := range lf.EnvVars {
+		if manifest.IsEnvVarAlreadySet(name, value) {
+			continue
+		}
+		if dryRun {
+			log.Infof("[dry-run] envVars: set %s=%s", name, value)
+			continue
+		}
+		log.Infof("envVars: set %s=%s", name, value)
+		if err := manifest.ApplyEnvVar(name, value); err != nil {
+			return err
+		}
+	}
+
+	for key, value := range lf.Dock {
+		if dryRun {
+			log.Infof("[dry-run] dock: set %s=%s", key, value)
+			continue
+		}
+		log.Infof("dock: set %s=%s", key, value)
+		if err := exec.Command("defaults", "write", "com.apple.dock", key, value).Run(); err != nil {
+			return errors.Wrapf(err, "failed to set dock preference %s", key)
+		}
+	}
+	if !dryRun && len(lf.Dock) > 0 {
+		if err := exec.Command("killall", "Dock").Run(); err != nil {
+			return errors.Wrap(err, "failed to restart Dock")
+		}
+	}
+
+	return nil
+}
+
+func applyBrewPkg(pkg PinnedPackage, dryRun bool) error {
+	req := installer.Requirement{Backend: "brew", Pkg: pkg.Name, Version: pkg.Version}
+	name := pkg.Name
+	if pkg.Version != "" {
+		name = fmt.Sprintf("%s@%s", pkg.Name, pkg.Version)
+	}
+	if dryRun {
+		log.Infof("[dry-run] brew: install %s", name)
+		return nil
+	}
+	log.Infof("brew: install %s", name)
+	if err := installer.Install(req); err != nil {
+		return errors.Wrapf(err, "failed to install %s", name)
+	}
+	return nil
+}
+
+func gitConfigValue(key string) (string, error) {
+	out, err := exec.Command("git", "config", "--global", key).Output()
+	if err != nil {
+		return "", err
+	}
+	return trimNewline(string(out)), nil
+}
+
+func trimNewline(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == '\n' || s[len(s)-1] == '\r') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func localSshFingerprintExists(fingerprint string) bool {
+	keys, err := snapshotSshKeys()
+	if err != nil {
+		return false
+	}
+	for _, k := range keys {
+		if k.Fingerprint == fingerprint {
+			return true
+		}
+	}
+	return false
+}